@@ -0,0 +1,103 @@
+package ftracker
+
+// Коэффициенты полинома Минетти метаболической стоимости бега/ходьбы по
+// уклону i (rise/run): C(i) = 155.4*i^5 - 30.4*i^4 - 43.3*i^3 + 46.3*i^2 + 19.5*i + 3.6.
+const (
+	minettiCoefI5 = 155.4
+	minettiCoefI4 = -30.4
+	minettiCoefI3 = -43.3
+	minettiCoefI2 = 46.3
+	minettiCoefI1 = 19.5
+	minettiCoefI0 = 3.6
+)
+
+// Диапазон уклона, для которого применим полином Минетти; значения за его
+// пределами обрезаются.
+const (
+	minettiGradeMin = -0.45
+	minettiGradeMax = 0.45
+)
+
+// minettiCostOfTransport возвращает метаболическую стоимость перемещения
+// (Дж/(кг*м)) по полиному Минетти для уклона grade, предварительно
+// обрезанного до диапазона [minettiGradeMin, minettiGradeMax].
+func minettiCostOfTransport(grade float64) float64 {
+	if grade < minettiGradeMin {
+		grade = minettiGradeMin
+	}
+	if grade > minettiGradeMax {
+		grade = minettiGradeMax
+	}
+
+	var i2 = grade * grade
+	var i3 = i2 * grade
+	var i4 = i3 * grade
+	var i5 = i4 * grade
+
+	return minettiCoefI5*i5 + minettiCoefI4*i4 + minettiCoefI3*i3 + minettiCoefI2*i2 + minettiCoefI1*grade + minettiCoefI0
+}
+
+// GradeFromElevation возвращает средний уклон (rise/run) тренировки по
+// набору/сбросу высоты ascentM/descentM на дистанции distanceKm.
+func GradeFromElevation(ascentM, descentM, distanceKm float64) float64 {
+	if distanceKm <= 0 {
+		return 0
+	}
+
+	return (ascentM - descentM) / (distanceKm * mInKm)
+}
+
+// minettiGradeMultiplier возвращает во сколько раз метаболическая стоимость
+// перемещения по уклону grade отличается от стоимости перемещения по
+// ровной поверхности (grade == 0), по полиному Минетти. На нём калибруются
+// *Graded-формулы: при grade == 0 множитель равен 1, и результат совпадает
+// с исходной формулой для ровной поверхности.
+//
+// Это сознательное отступление от "буквального" расчёта Минетти
+// (C(i)*weight*distance/4184 в ккал): тот не согласован с
+// RunningSpentCalories/WalkingSpentCalories на ровной поверхности и давал бы
+// разрыв в точке grade == 0. Вместо него существующая формула масштабируется
+// отношением C(grade)/C(0), так что граница grade == 0 по построению
+// совпадает с плоской формулой, а уклон лишь корректирует её в нужную
+// сторону. Не заменять на буквальный расчёт Минетти, не восстановив
+// согласованность на стыке.
+func minettiGradeMultiplier(grade float64) float64 {
+	var baseline = minettiCostOfTransport(0)
+	if baseline == 0 {
+		return 1
+	}
+
+	return minettiCostOfTransport(grade) / baseline
+}
+
+// RunningSpentCaloriesGraded возвращает количество потраченных калорий при
+// беге с поправкой на уклон grade (rise/run, например 0.05 для подъёма 5%):
+// RunningSpentCalories, масштабированная множителем стоимости перемещения
+// по полиному Минетти. При grade == 0 результат совпадает с
+// RunningSpentCalories.
+//
+// Параметры:
+//
+// action int — количество шагов.
+// weight float64 — вес пользователя.
+// duration float64 — длительность тренировки в часах.
+// grade float64 — средний уклон трассы, rise/run.
+func RunningSpentCaloriesGraded(action int, weight, duration, grade float64) float64 {
+	return RunningSpentCalories(action, weight, duration) * minettiGradeMultiplier(grade)
+}
+
+// WalkingSpentCaloriesGraded возвращает количество потраченных калорий при
+// ходьбе с поправкой на уклон grade (rise/run, например 0.05 для подъёма
+// 5%): WalkingSpentCalories, масштабированная множителем стоимости
+// перемещения по полиному Минетти. При grade == 0 результат совпадает с
+// WalkingSpentCalories.
+//
+// Параметры:
+//
+// action int — количество шагов.
+// duration float64 — длительность тренировки в часах.
+// weight, height float64 — вес и рост пользователя.
+// grade float64 — средний уклон трассы, rise/run.
+func WalkingSpentCaloriesGraded(action int, duration, weight, height, grade float64) float64 {
+	return WalkingSpentCalories(action, duration, weight, height) * minettiGradeMultiplier(grade)
+}
@@ -0,0 +1,58 @@
+package ftracker
+
+import "testing"
+
+func TestRunningSpentCaloriesGraded_FlatMatchesOriginal(t *testing.T) {
+	var flat = RunningSpentCalories(1000, 70, 1)
+	var graded = RunningSpentCaloriesGraded(1000, 70, 1, 0)
+
+	if graded != flat {
+		t.Errorf("RunningSpentCaloriesGraded(grade=0) = %v, ожидалось совпадение с RunningSpentCalories = %v", graded, flat)
+	}
+}
+
+func TestRunningSpentCaloriesGraded_UphillIncreasesOverFlat(t *testing.T) {
+	var flat = RunningSpentCalories(1000, 70, 1)
+	var uphill = RunningSpentCaloriesGraded(1000, 70, 1, 0.085)
+	var downhill = RunningSpentCaloriesGraded(1000, 70, 1, -0.085)
+
+	if uphill <= flat {
+		t.Errorf("бег в подъём (%v) не превышает бег по ровной поверхности (%v)", uphill, flat)
+	}
+	if downhill >= flat {
+		t.Errorf("бег под уклон (%v) не меньше бега по ровной поверхности (%v)", downhill, flat)
+	}
+}
+
+func TestWalkingSpentCaloriesGraded_FlatMatchesOriginal(t *testing.T) {
+	var flat = WalkingSpentCalories(1000, 1, 70, 175)
+	var graded = WalkingSpentCaloriesGraded(1000, 1, 70, 175, 0)
+
+	if graded != flat {
+		t.Errorf("WalkingSpentCaloriesGraded(grade=0) = %v, ожидалось совпадение с WalkingSpentCalories = %v", graded, flat)
+	}
+}
+
+func TestWalkingSpentCaloriesGraded_UphillIncreasesOverFlat(t *testing.T) {
+	var flat = WalkingSpentCalories(1000, 1, 70, 175)
+	var uphill = WalkingSpentCaloriesGraded(1000, 1, 70, 175, 0.085)
+
+	if uphill <= flat {
+		t.Errorf("ходьба в подъём (%v) не превышает ходьбу по ровной поверхности (%v)", uphill, flat)
+	}
+}
+
+func TestGradeFromElevation(t *testing.T) {
+	if got := GradeFromElevation(0, 0, 5); got != 0 {
+		t.Errorf("GradeFromElevation без набора/сброса высоты = %v, ожидалось 0", got)
+	}
+	if got := GradeFromElevation(0, 0, 0); got != 0 {
+		t.Errorf("GradeFromElevation с нулевой дистанцией = %v, ожидалось 0", got)
+	}
+
+	var got = GradeFromElevation(100, 0, 1)
+	var want = 100.0 / 1000.0
+	if got != want {
+		t.Errorf("GradeFromElevation(100, 0, 1) = %v, ожидалось %v", got, want)
+	}
+}
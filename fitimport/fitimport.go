@@ -0,0 +1,113 @@
+// Package fitimport разбирает FIT-файлы (Garmin/ANT) с активностями и
+// приводит их к модели тренировок пакета ftracker, чтобы не заполнять
+// action/duration/weight вручную для тренировок, записанных часами.
+package fitimport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"ftracker"
+)
+
+// Lap - один круг (отрезок) тренировки из lap-сообщения FIT-файла.
+type Lap struct {
+	DistanceKm  float64
+	ElapsedSec  float64
+	Calories    float64
+	AvgSpeedKmH float64
+}
+
+// Session - одна тренировка, собранная из session-, lap- и record-сообщений
+// FIT-файла.
+type Session struct {
+	Type            ftracker.TrainingType
+	TotalDistanceKm float64
+	MovingTimeSec   float64
+	Calories        float64
+	ElevationGainM  float64
+	ElevationLossM  float64
+	AvgHeartRate    int
+	MaxHeartRate    int
+	Laps            []Lap
+}
+
+// ImportFile читает FIT-файл по пути path и возвращает собранные из него
+// тренировки.
+func ImportFile(path string) ([]Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("fitimport: не удалось открыть файл: %w", err)
+	}
+	defer f.Close()
+
+	return ImportReader(f)
+}
+
+// ImportReader читает содержимое FIT-файла из r и возвращает собранные из
+// него тренировки.
+func ImportReader(r io.Reader) ([]Session, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("fitimport: не удалось прочитать файл: %w", err)
+	}
+
+	header, err := parseHeader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var dataStart = int(header.headerSize)
+	var dataEnd = dataStart + int(header.dataSize)
+	if dataEnd+2 > len(content) {
+		return nil, fmt.Errorf("fitimport: файл короче, чем заявлено в заголовке")
+	}
+
+	// CRC в FIT-файле считается по всему файлу, кроме двух последних байт, в
+	// которых она хранится, поэтому в неё нужно включить и заголовок.
+	var cr = &crcReader{r: bytes.NewReader(content[dataStart:dataEnd])}
+	for _, b := range content[:dataStart] {
+		cr.crc = fitUpdateCRC(cr.crc, b)
+	}
+
+	messages, err := parseRecords(cr, header.dataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyCRC(cr, bytes.NewReader(content[dataEnd:])); err != nil {
+		return nil, err
+	}
+
+	return buildSessions(messages), nil
+}
+
+// trainingTypeNames - обратное соответствие ftracker.TrainingType -> имя, под
+// которым вид тренировки зарегистрирован в ftracker.AvailableTrainings.
+var trainingTypeNames = invertAvailableTrainings()
+
+func invertAvailableTrainings() map[ftracker.TrainingType]string {
+	var names = make(map[ftracker.TrainingType]string, len(ftracker.AvailableTrainings))
+
+	for name, trainingType := range ftracker.AvailableTrainings {
+		names[trainingType] = name
+	}
+
+	return names
+}
+
+// ToTrainingInfo форматирует Session через ftracker.FormatTrainingSummary,
+// то есть в том же виде, в котором ftracker.ShowTrainingInfo отдаёт
+// тренировки, введённые пользователем вручную. Дистанция и калории
+// FIT-файла уже посчитаны часами, поэтому здесь они не пересчитываются через
+// формулы ftracker, а используются как есть.
+func (s Session) ToTrainingInfo() string {
+	var speed float64
+	if s.MovingTimeSec > 0 {
+		speed = s.TotalDistanceKm / (s.MovingTimeSec / 3600)
+	}
+
+	return ftracker.FormatTrainingSummary(trainingTypeNames[s.Type], s.MovingTimeSec/3600, s.TotalDistanceKm, speed, s.Calories)
+}
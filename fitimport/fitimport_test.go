@@ -0,0 +1,191 @@
+package fitimport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"ftracker"
+)
+
+// buildFitFixture собирает минимальный валидный FIT-файл с одним
+// session-сообщением: короткий (12-байтный) заголовок, одно definition- и
+// одно data-сообщение для session, корректная CRC-16 по всему файлу.
+func buildFitFixture(t *testing.T) []byte {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	// Definition-сообщение: local type 0, session (globalMesgNum=18),
+	// поля sport/totalElapsed/totalDistance/totalCalories.
+	body.WriteByte(recordHeaderDefinitionFlag)
+	body.WriteByte(0) // reserved.
+	body.WriteByte(0) // architecture: little-endian.
+	var globalMesgNum [2]byte
+	binary.LittleEndian.PutUint16(globalMesgNum[:], mesgNumSession)
+	body.Write(globalMesgNum[:])
+	body.WriteByte(4) // число полей.
+	body.Write([]byte{fieldSessionSport, 1, 0x00})
+	body.Write([]byte{fieldSessionTotalElapsed, 4, 0x86})
+	body.Write([]byte{fieldSessionTotalDistance, 4, 0x86})
+	body.Write([]byte{fieldSessionTotalCalories, 2, 0x84})
+
+	// Data-сообщение с теми же полями: бег, 3600 сек, 5 км, 300 ккал.
+	body.WriteByte(0) // local type 0, без флага definition.
+	body.WriteByte(1) // sport = бег.
+	var elapsed, distance [4]byte
+	binary.LittleEndian.PutUint32(elapsed[:], 3600000) // 3600 сек * scaleElapsed.
+	binary.LittleEndian.PutUint32(distance[:], 500000) // 5 км * scaleDistance * 1000.
+	body.Write(elapsed[:])
+	body.Write(distance[:])
+	var calories [2]byte
+	binary.LittleEndian.PutUint16(calories[:], 300)
+	body.Write(calories[:])
+
+	var header = make([]byte, fitHeaderSizeShort)
+	header[0] = fitHeaderSizeShort
+	header[1] = 0x10 // protocolVer, произвольное значение.
+	binary.LittleEndian.PutUint16(header[2:4], 100)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(body.Len()))
+	copy(header[8:12], fitSignature)
+
+	var file = append(append([]byte{}, header...), body.Bytes()...)
+
+	var crc uint16
+	for _, b := range file {
+		crc = fitUpdateCRC(crc, b)
+	}
+
+	var crcBytes [2]byte
+	binary.LittleEndian.PutUint16(crcBytes[:], crc)
+
+	return append(file, crcBytes[:]...)
+}
+
+func TestImportReader_RoundTrip(t *testing.T) {
+	var sessions, err = ImportReader(bytes.NewReader(buildFitFixture(t)))
+	if err != nil {
+		t.Fatalf("ImportReader вернул ошибку: %v", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("ImportReader вернул %d тренировок, ожидалась 1", len(sessions))
+	}
+
+	var session = sessions[0]
+	if session.Type != ftracker.Run {
+		t.Errorf("Type = %v, ожидался ftracker.Run", session.Type)
+	}
+	if session.MovingTimeSec != 3600 {
+		t.Errorf("MovingTimeSec = %v, ожидалось 3600", session.MovingTimeSec)
+	}
+	if session.TotalDistanceKm != 5 {
+		t.Errorf("TotalDistanceKm = %v, ожидалось 5", session.TotalDistanceKm)
+	}
+	if session.Calories != 300 {
+		t.Errorf("Calories = %v, ожидалось 300", session.Calories)
+	}
+
+	var want = ftracker.FormatTrainingSummary("Бег", 1, 5, 5, 300)
+	if got := session.ToTrainingInfo(); got != want {
+		t.Errorf("ToTrainingInfo() = %q, ожидалось %q", got, want)
+	}
+}
+
+// buildFitFixtureNoSessionDistance собирает FIT-файл, в котором
+// session-сообщение не несёт total_distance (поле нулевое), а дистанция
+// вместо этого приходит из двух record-сообщений - так, как это бывает у
+// часов, которые не считают итоговую дистанцию сессии сами.
+func buildFitFixtureNoSessionDistance(t *testing.T) []byte {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	// Definition-сообщение для record (globalMesgNum=20): только distance.
+	body.WriteByte(recordHeaderDefinitionFlag | 1) // local type 1.
+	body.WriteByte(0)
+	body.WriteByte(0)
+	var recordMesgNum [2]byte
+	binary.LittleEndian.PutUint16(recordMesgNum[:], mesgNumRecord)
+	body.Write(recordMesgNum[:])
+	body.WriteByte(1)
+	body.Write([]byte{fieldRecordDistance, 4, 0x86})
+
+	// Два record-сообщения (local type 1, без флага definition) с накопительной
+	// дистанцией 2 км и 5 км - в качестве запасного источника должно взяться
+	// последнее значение.
+	var recordDistance [4]byte
+	body.WriteByte(1)
+	binary.LittleEndian.PutUint32(recordDistance[:], 200000)
+	body.Write(recordDistance[:])
+	body.WriteByte(1)
+	binary.LittleEndian.PutUint32(recordDistance[:], 500000)
+	body.Write(recordDistance[:])
+
+	// Definition-сообщение для session (local type 0): sport/totalElapsed/
+	// totalDistance/totalCalories, как в buildFitFixture.
+	body.WriteByte(recordHeaderDefinitionFlag)
+	body.WriteByte(0)
+	body.WriteByte(0)
+	var sessionMesgNum [2]byte
+	binary.LittleEndian.PutUint16(sessionMesgNum[:], mesgNumSession)
+	body.Write(sessionMesgNum[:])
+	body.WriteByte(4)
+	body.Write([]byte{fieldSessionSport, 1, 0x00})
+	body.Write([]byte{fieldSessionTotalElapsed, 4, 0x86})
+	body.Write([]byte{fieldSessionTotalDistance, 4, 0x86})
+	body.Write([]byte{fieldSessionTotalCalories, 2, 0x84})
+
+	// Data-сообщение для session: total_distance = 0.
+	body.WriteByte(0)
+	body.WriteByte(1) // sport = бег.
+	var elapsed, zeroDistance [4]byte
+	binary.LittleEndian.PutUint32(elapsed[:], 3600000)
+	body.Write(elapsed[:])
+	body.Write(zeroDistance[:])
+	var calories [2]byte
+	binary.LittleEndian.PutUint16(calories[:], 300)
+	body.Write(calories[:])
+
+	var header = make([]byte, fitHeaderSizeShort)
+	header[0] = fitHeaderSizeShort
+	header[1] = 0x10
+	binary.LittleEndian.PutUint16(header[2:4], 100)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(body.Len()))
+	copy(header[8:12], fitSignature)
+
+	var file = append(append([]byte{}, header...), body.Bytes()...)
+
+	var crc uint16
+	for _, b := range file {
+		crc = fitUpdateCRC(crc, b)
+	}
+
+	var crcBytes [2]byte
+	binary.LittleEndian.PutUint16(crcBytes[:], crc)
+
+	return append(file, crcBytes[:]...)
+}
+
+func TestImportReader_FallsBackToRecordDistance(t *testing.T) {
+	var sessions, err = ImportReader(bytes.NewReader(buildFitFixtureNoSessionDistance(t)))
+	if err != nil {
+		t.Fatalf("ImportReader вернул ошибку: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("ImportReader вернул %d тренировок, ожидалась 1", len(sessions))
+	}
+
+	if got := sessions[0].TotalDistanceKm; got != 5 {
+		t.Errorf("TotalDistanceKm = %v, ожидалось 5 (запасной источник - record)", got)
+	}
+}
+
+func TestImportReader_RejectsBadCRC(t *testing.T) {
+	var file = buildFitFixture(t)
+	file[len(file)-1] ^= 0xFF // портим CRC.
+
+	if _, err := ImportReader(bytes.NewReader(file)); err == nil {
+		t.Error("ImportReader не вернул ошибку при испорченной CRC")
+	}
+}
@@ -0,0 +1,103 @@
+package fitimport
+
+import "ftracker"
+
+// Номера полей внутри сообщений session/lap/record, которые
+// нужны для построения Session/Lap. Значения соответствуют профилю
+// сообщений FIT SDK.
+const (
+	fieldSessionSport         = 5
+	fieldSessionTotalElapsed  = 7
+	fieldSessionTotalDistance = 9
+	fieldSessionTotalCalories = 11
+	fieldSessionAvgHeartRate  = 16
+	fieldSessionMaxHeartRate  = 17
+	fieldSessionTotalAscent   = 22
+	fieldSessionTotalDescent  = 23
+
+	fieldLapTotalElapsed  = 7
+	fieldLapTotalDistance = 9
+	fieldLapTotalCalories = 11
+	fieldLapAvgSpeed      = 13
+
+	fieldRecordDistance = 5
+)
+
+// Масштабирующие коэффициенты полей FIT SDK: хранимое целое делится на
+// коэффициент, чтобы получить значение в "человеческих" единицах.
+const (
+	scaleDistance = 100.0  // сантиметры -> метры.
+	scaleSpeed    = 1000.0 // мм/с -> м/с.
+	scaleElapsed  = 1000.0 // миллисекунды -> секунды.
+)
+
+// fitSportToTrainingType переводит FIT-enum sport в ближайший вид тренировки
+// из пакета ftracker. Значения соответствуют профилю sport FIT SDK.
+func fitSportToTrainingType(sport uint64) ftracker.TrainingType {
+	switch sport {
+	case 1:
+		return ftracker.Run
+	case 2:
+		return ftracker.Cycling
+	case 5:
+		return ftracker.Swim
+	case 10:
+		return ftracker.Strength
+	case 15:
+		return ftracker.Rowing
+	default:
+		return ftracker.Walk
+	}
+}
+
+// buildSessions группирует разобранные FIT-сообщения в Session: одна
+// session-запись с принадлежащими ей lap-записями; если в session-сообщении
+// нет total_distance, в качестве запасного источника дистанции берётся
+// последнее значение distance из record-сообщений этой тренировки (поле
+// накопительное - растёт от начала тренировки до конца).
+func buildSessions(messages []rawMessage) []Session {
+	var sessions []Session
+	var currentLaps []Lap
+	var lastRecordDistanceKm float64
+
+	for _, msg := range messages {
+		switch msg.globalMesgNum {
+		case mesgNumRecord:
+			if d, isExist := msg.fields[fieldRecordDistance]; isExist {
+				lastRecordDistanceKm = float64(d) / scaleDistance / 1000
+			}
+
+		case mesgNumLap:
+			currentLaps = append(currentLaps, Lap{
+				DistanceKm:  float64(msg.fields[fieldLapTotalDistance]) / scaleDistance / 1000,
+				ElapsedSec:  float64(msg.fields[fieldLapTotalElapsed]) / scaleElapsed,
+				Calories:    float64(msg.fields[fieldLapTotalCalories]),
+				AvgSpeedKmH: float64(msg.fields[fieldLapAvgSpeed]) / scaleSpeed * 3.6,
+			})
+
+		case mesgNumSession:
+			var totalDistanceKm = float64(msg.fields[fieldSessionTotalDistance]) / scaleDistance / 1000
+			if totalDistanceKm == 0 {
+				totalDistanceKm = lastRecordDistanceKm
+			}
+
+			var session = Session{
+				Type:            fitSportToTrainingType(msg.fields[fieldSessionSport]),
+				TotalDistanceKm: totalDistanceKm,
+				MovingTimeSec:   float64(msg.fields[fieldSessionTotalElapsed]) / scaleElapsed,
+				Calories:        float64(msg.fields[fieldSessionTotalCalories]),
+				ElevationGainM:  float64(msg.fields[fieldSessionTotalAscent]),
+				ElevationLossM:  float64(msg.fields[fieldSessionTotalDescent]),
+				AvgHeartRate:    int(msg.fields[fieldSessionAvgHeartRate]),
+				MaxHeartRate:    int(msg.fields[fieldSessionMaxHeartRate]),
+				Laps:            currentLaps,
+			}
+
+			sessions = append(sessions, session)
+			currentLaps = nil
+			lastRecordDistanceKm = 0
+		}
+	}
+
+	return sessions
+}
@@ -0,0 +1,366 @@
+package fitimport
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Размеры и сигнатура заголовка FIT-файла.
+const (
+	fitSignature       = ".FIT"
+	fitHeaderSizeShort = 12
+	fitHeaderSizeLong  = 14
+)
+
+// Биты заголовка записи (record header), см. FIT Protocol, Record Header.
+const (
+	recordHeaderDefinitionFlag       = 0x40
+	recordHeaderDeveloperDataFlag    = 0x20
+	recordHeaderCompressedTimestamp  = 0x80
+	recordHeaderLocalMessageTypeMask = 0x0F
+)
+
+// Номера глобальных FIT-сообщений, которые умеет разбирать этот пакет.
+const (
+	mesgNumSession = 18
+	mesgNumLap     = 19
+	mesgNumRecord  = 20
+)
+
+// fieldDef - описание одного поля сообщения из definition-записи.
+type fieldDef struct {
+	num      byte
+	size     byte
+	baseType byte
+}
+
+// devFieldDef - описание developer-поля; данные по нему читаются, но
+// интерпретируются только как необработанные байты.
+type devFieldDef struct {
+	num      byte
+	size     byte
+	devIndex byte
+}
+
+// messageDef - definition-сообщение, накопленное для одного local message type.
+type messageDef struct {
+	globalMesgNum uint16
+	bigEndian     bool
+	fields        []fieldDef
+	devFields     []devFieldDef
+}
+
+// rawMessage - разобранное data-сообщение: номер глобального типа сообщения
+// и значения полей, проиндексированные по номеру поля.
+type rawMessage struct {
+	globalMesgNum uint16
+	fields        map[byte]uint64
+	signed        map[byte]bool
+}
+
+// crcReader читает тело FIT-файла и одновременно считает CRC-16, чтобы
+// сверить её с контрольной суммой, записанной в конце файла.
+type crcReader struct {
+	r   io.Reader
+	crc uint16
+}
+
+var fitCRCTable = [16]uint16{
+	0x0000, 0xCC01, 0xD801, 0x1400,
+	0xF001, 0x3C00, 0x2800, 0xE401,
+	0xA001, 0x6C00, 0x7800, 0xB401,
+	0x5000, 0x9C01, 0x8801, 0x4400,
+}
+
+// fitUpdateCRC реализует эталонный алгоритм CRC-16 из FIT Protocol (таблично,
+// по полубайтам).
+func fitUpdateCRC(crc uint16, b byte) uint16 {
+	var tmp = fitCRCTable[crc&0xF]
+	crc = (crc >> 4) & 0x0FFF
+	crc = crc ^ tmp ^ fitCRCTable[b&0xF]
+
+	tmp = fitCRCTable[crc&0xF]
+	crc = (crc >> 4) & 0x0FFF
+	crc = crc ^ tmp ^ fitCRCTable[(b>>4)&0xF]
+
+	return crc
+}
+
+func (c *crcReader) ReadByte() (byte, error) {
+	var buf [1]byte
+	if _, err := io.ReadFull(c.r, buf[:]); err != nil {
+		return 0, err
+	}
+
+	c.crc = fitUpdateCRC(c.crc, buf[0])
+
+	return buf[0], nil
+}
+
+func (c *crcReader) ReadBytes(n int) ([]byte, error) {
+	var buf = make([]byte, n)
+	if _, err := io.ReadFull(c.r, buf); err != nil {
+		return nil, err
+	}
+
+	for _, b := range buf {
+		c.crc = fitUpdateCRC(c.crc, b)
+	}
+
+	return buf, nil
+}
+
+// fitHeader - разобранный заголовок FIT-файла.
+type fitHeader struct {
+	headerSize   byte
+	protocolVer  byte
+	profileVer   uint16
+	dataSize     uint32
+	hasHeaderCRC bool
+	headerCRC    uint16
+}
+
+func parseHeader(r io.Reader) (fitHeader, error) {
+	var peek = make([]byte, 1)
+	if _, err := io.ReadFull(r, peek); err != nil {
+		return fitHeader{}, fmt.Errorf("fitimport: не удалось прочитать заголовок: %w", err)
+	}
+
+	var headerSize = peek[0]
+	if headerSize != fitHeaderSizeShort && headerSize != fitHeaderSizeLong {
+		return fitHeader{}, fmt.Errorf("fitimport: неверный размер заголовка: %d", headerSize)
+	}
+
+	var rest = make([]byte, int(headerSize)-1)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return fitHeader{}, fmt.Errorf("fitimport: неполный заголовок: %w", err)
+	}
+
+	var buf = append(peek, rest...)
+
+	var signature = string(buf[8:12])
+	if signature != fitSignature {
+		return fitHeader{}, fmt.Errorf("fitimport: неверная сигнатура файла: %q", signature)
+	}
+
+	var header = fitHeader{
+		headerSize:  headerSize,
+		protocolVer: buf[1],
+		profileVer:  binary.LittleEndian.Uint16(buf[2:4]),
+		dataSize:    binary.LittleEndian.Uint32(buf[4:8]),
+	}
+
+	if headerSize == fitHeaderSizeLong {
+		header.hasHeaderCRC = true
+		header.headerCRC = binary.LittleEndian.Uint16(buf[12:14])
+	}
+
+	return header, nil
+}
+
+// baseTypeSize возвращает размер в байтах базового FIT-типа. Номер типа
+// задают младшие 5 бит, по нему размер берётся из таблицы; старший бит
+// (признак многобайтового типа) на размер не влияет.
+func baseTypeSize(baseType byte) int {
+	switch baseType & 0x1F {
+	case 0x00, 0x01, 0x02, 0x0A, 0x07, 0x0D:
+		return 1
+	case 0x03, 0x04, 0x0B:
+		return 2
+	case 0x05, 0x06, 0x0C, 0x08:
+		return 4
+	case 0x09, 0x0E, 0x0F, 0x10:
+		return 8
+	default:
+		return 1
+	}
+}
+
+// baseTypeSigned сообщает, что базовый тип - знаковое целое.
+func baseTypeSigned(baseType byte) bool {
+	switch baseType & 0x1F {
+	case 0x01, 0x03, 0x05, 0x0E:
+		return true
+	default:
+		return false
+	}
+}
+
+func readUint(buf []byte, bigEndian bool) uint64 {
+	var order binary.ByteOrder = binary.LittleEndian
+	if bigEndian {
+		order = binary.BigEndian
+	}
+
+	switch len(buf) {
+	case 1:
+		return uint64(buf[0])
+	case 2:
+		return uint64(order.Uint16(buf))
+	case 4:
+		return uint64(order.Uint32(buf))
+	case 8:
+		return order.Uint64(buf)
+	default:
+		// Строковые и произвольные byte-поля читаются как набор байт, а не
+		// как число; для них возвращается только первый байт.
+		if len(buf) == 0 {
+			return 0
+		}
+		return uint64(buf[0])
+	}
+}
+
+// parseRecords читает definition- и data-сообщения из тела FIT-файла и
+// возвращает все разобранные data-сообщения.
+func parseRecords(cr *crcReader, dataSize uint32) ([]rawMessage, error) {
+	var defs = map[byte]*messageDef{}
+	var messages []rawMessage
+	var read uint32
+
+	for read < dataSize {
+		headerByte, err := cr.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("fitimport: не удалось прочитать заголовок записи: %w", err)
+		}
+		read++
+
+		if headerByte&recordHeaderCompressedTimestamp != 0 {
+			return nil, fmt.Errorf("fitimport: заголовки записей со сжатой меткой времени не поддерживаются")
+		}
+
+		var localType = headerByte & recordHeaderLocalMessageTypeMask
+
+		if headerByte&recordHeaderDefinitionFlag != 0 {
+			n, def, err := parseDefinitionMessage(cr, headerByte)
+			if err != nil {
+				return nil, err
+			}
+
+			read += n
+			defs[localType] = def
+
+			continue
+		}
+
+		def, isExist := defs[localType]
+		if !isExist {
+			return nil, fmt.Errorf("fitimport: data-сообщение ссылается на неизвестный local message type %d", localType)
+		}
+
+		n, msg, err := parseDataMessage(cr, def)
+		if err != nil {
+			return nil, err
+		}
+
+		read += n
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+func parseDefinitionMessage(cr *crcReader, headerByte byte) (uint32, *messageDef, error) {
+	var n uint32
+
+	fixed, err := cr.ReadBytes(5)
+	if err != nil {
+		return n, nil, fmt.Errorf("fitimport: не удалось прочитать definition-сообщение: %w", err)
+	}
+	n += 5
+
+	var bigEndian = fixed[1] == 1
+	var def = &messageDef{bigEndian: bigEndian}
+
+	if bigEndian {
+		def.globalMesgNum = binary.BigEndian.Uint16(fixed[2:4])
+	} else {
+		def.globalMesgNum = binary.LittleEndian.Uint16(fixed[2:4])
+	}
+
+	var numFields = int(fixed[4])
+
+	for i := 0; i < numFields; i++ {
+		raw, err := cr.ReadBytes(3)
+		if err != nil {
+			return n, nil, fmt.Errorf("fitimport: не удалось прочитать описание поля: %w", err)
+		}
+		n += 3
+
+		def.fields = append(def.fields, fieldDef{num: raw[0], size: raw[1], baseType: raw[2]})
+	}
+
+	if headerByte&recordHeaderDeveloperDataFlag != 0 {
+		countBuf, err := cr.ReadBytes(1)
+		if err != nil {
+			return n, nil, fmt.Errorf("fitimport: не удалось прочитать количество developer-полей: %w", err)
+		}
+		n++
+
+		for i := 0; i < int(countBuf[0]); i++ {
+			raw, err := cr.ReadBytes(3)
+			if err != nil {
+				return n, nil, fmt.Errorf("fitimport: не удалось прочитать developer-поле: %w", err)
+			}
+			n += 3
+
+			def.devFields = append(def.devFields, devFieldDef{num: raw[0], size: raw[1], devIndex: raw[2]})
+		}
+	}
+
+	return n, def, nil
+}
+
+func parseDataMessage(cr *crcReader, def *messageDef) (uint32, rawMessage, error) {
+	var n uint32
+	var msg = rawMessage{
+		globalMesgNum: def.globalMesgNum,
+		fields:        map[byte]uint64{},
+		signed:        map[byte]bool{},
+	}
+
+	for _, f := range def.fields {
+		size := int(f.size)
+		if size == 0 {
+			size = baseTypeSize(f.baseType)
+		}
+
+		raw, err := cr.ReadBytes(size)
+		if err != nil {
+			return n, msg, fmt.Errorf("fitimport: не удалось прочитать значение поля: %w", err)
+		}
+		n += uint32(size)
+
+		msg.fields[f.num] = readUint(raw, def.bigEndian)
+		msg.signed[f.num] = baseTypeSigned(f.baseType)
+	}
+
+	for _, f := range def.devFields {
+		if _, err := cr.ReadBytes(int(f.size)); err != nil {
+			return n, msg, fmt.Errorf("fitimport: не удалось прочитать developer-значение: %w", err)
+		}
+		n += uint32(f.size)
+	}
+
+	return n, msg, nil
+}
+
+// verifyCRC сверяет накопленную по ходу чтения CRC с контрольной суммой,
+// записанной в последних двух байтах файла.
+func verifyCRC(cr *crcReader, r io.Reader) error {
+	footer, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("fitimport: не удалось прочитать CRC файла: %w", err)
+	}
+	if len(footer) < 2 {
+		return fmt.Errorf("fitimport: файл слишком короткий для хранения CRC")
+	}
+
+	var fileCRC = binary.LittleEndian.Uint16(footer[len(footer)-2:])
+	if fileCRC != cr.crc {
+		return fmt.Errorf("fitimport: CRC не совпадает: ожидали %04X, посчитали %04X", fileCRC, cr.crc)
+	}
+
+	return nil
+}
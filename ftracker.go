@@ -1,9 +1,6 @@
 package ftracker
 
-import (
-	"fmt"
-	"math"
-)
+import "math"
 
 // Основные константы, необходимые для расчетов.
 const (
@@ -39,13 +36,19 @@ const (
 	Run TrainingType = iota
 	Walk
 	Swim
+	Strength
+	Cycling
+	Rowing
 )
 
 // AvailableTrainings - доступные виды тренировок
 var AvailableTrainings = map[string]TrainingType{
-	"Бег":      Run,
-	"Ходьба":   Walk,
-	"Плавание": Swim,
+	"Бег":       Run,
+	"Ходьба":    Walk,
+	"Плавание":  Swim,
+	"Силовая":   Strength,
+	"Велосипед": Cycling,
+	"Гребля":    Rowing,
 }
 
 // distance возвращает дистанцию(в километрах), которую преодолел пользователь за время тренировки.
@@ -73,46 +76,6 @@ func meanSpeed(action int, duration float64) float64 {
 	return distance / duration
 }
 
-// ShowTrainingInfo возвращает строку с информацией о тренировке.
-//
-// Параметры:
-//
-// action int — количество совершенных действий(число шагов при ходьбе и беге, либо гребков при плавании).
-// trainingType string — вид тренировки(Бег, Ходьба, Плавание).
-// duration float64 — длительность тренировки в часах.
-func ShowTrainingInfo(action int, trainingType string, duration, weight, height float64, lengthPool, countPool int) string {
-	var training, isExist = AvailableTrainings[trainingType]
-
-	if !isExist {
-		return "неизвестный тип тренировки"
-	}
-
-	var distance = distance(action)
-	var speed = meanSpeed(action, duration)
-	var calories float64
-
-	switch training {
-	case Run:
-		calories = RunningSpentCalories(action, weight, duration)
-	case Walk:
-		calories = WalkingSpentCalories(action, duration, weight, height)
-	case Swim:
-		speed = swimmingMeanSpeed(lengthPool, countPool, duration)
-		calories = SwimmingSpentCalories(lengthPool, countPool, duration, weight)
-	}
-
-	var formatMessage = "Тип тренировки: %s\n" +
-		"Длительность: %.2f ч.\n" +
-		"Дистанция: %.2f км.\n" +
-		"Скорость: %.2f км/ч\n" +
-		"Сожгли калорий: %.2f\n"
-
-	return fmt.Sprintf(
-		formatMessage,
-		trainingType, duration, distance, speed, calories,
-	)
-}
-
 // RunningSpentCalories возвращает количество потраченных колорий при беге.
 //
 // Параметры:
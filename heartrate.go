@@ -0,0 +1,220 @@
+package ftracker
+
+import "fmt"
+
+// secInHour - количество секунд в часе, используется при переводе
+// промежутков между отметками пульса в часы.
+const secInHour = 3600.0
+
+// Sex - пол пользователя, влияет на формулу расчета калорий по пульсу.
+type Sex int
+
+const (
+	Male Sex = iota
+	Female
+)
+
+// Константы для расчета калорий по формуле Кейтеля.
+const (
+	keytelMaleConst    = -55.0969
+	keytelMaleHR       = 0.6309
+	keytelMaleWeight   = 0.1988
+	keytelMaleAge      = 0.2017
+	keytelFemaleConst  = -20.4022
+	keytelFemaleHR     = 0.4472
+	keytelFemaleWeight = -0.1263
+	keytelFemaleAge    = 0.074
+	keytelJoulesInKcal = 4.184
+	minInHourFloat     = 60.0
+)
+
+// Границы зон пульса по методу Карвонена, доля от HRR (резерва пульса).
+const (
+	hrZone1Low  = 0.5
+	hrZone1High = 0.6
+	hrZone2High = 0.7
+	hrZone3High = 0.8
+	hrZone4High = 0.9
+	hrZone5High = 1.0
+)
+
+// HRSample - одна отметка пульса во время тренировки.
+type HRSample struct {
+	TimeOffsetSec int // смещение отметки относительно начала тренировки, сек.
+	BPM           int // пульс в этот момент, уд/мин.
+}
+
+// HRZoneBreakdown - разбивка тренировки по зонам пульса, посчитанная методом
+// Карвонена: Zone1 - разминка, Zone2 - жиросжигание, Zone3 - кардио,
+// Zone4 - анаэробная, Zone5 - максимальная.
+type HRZoneBreakdown struct {
+	MaxHR int
+	MinHR int
+	AvgHR int
+
+	Zone1Seconds int
+	Zone2Seconds int
+	Zone3Seconds int
+	Zone4Seconds int
+	Zone5Seconds int
+
+	Zone1Percent float64
+	Zone2Percent float64
+	Zone3Percent float64
+	Zone4Percent float64
+	Zone5Percent float64
+}
+
+// HeartRateZones считает зоны пульса методом Карвонена по последовательности
+// отметок пульса samples.
+//
+// Параметры:
+//
+// samples []HRSample — отметки пульса, отсортированные по TimeOffsetSec.
+// ageYears int — возраст пользователя, используется для оценки максимального пульса (220 - возраст).
+// restingHR int — пульс покоя пользователя.
+func HeartRateZones(samples []HRSample, ageYears int, restingHR int) HRZoneBreakdown {
+	var breakdown HRZoneBreakdown
+	if len(samples) == 0 {
+		return breakdown
+	}
+
+	var maxAgeHR = 220 - ageYears
+	var hrr = float64(maxAgeHR - restingHR)
+
+	var zone2Bound = float64(restingHR) + hrr*hrZone1High
+	var zone3Bound = float64(restingHR) + hrr*hrZone2High
+	var zone4Bound = float64(restingHR) + hrr*hrZone3High
+	var zone5Bound = float64(restingHR) + hrr*hrZone4High
+
+	var sumHR, minHR, maxHR = 0, samples[0].BPM, samples[0].BPM
+
+	for i, sample := range samples {
+		if sample.BPM < minHR {
+			minHR = sample.BPM
+		}
+		if sample.BPM > maxHR {
+			maxHR = sample.BPM
+		}
+		sumHR += sample.BPM
+
+		if i == len(samples)-1 {
+			continue
+		}
+
+		var segmentSec = samples[i+1].TimeOffsetSec - sample.TimeOffsetSec
+		if segmentSec <= 0 {
+			continue
+		}
+
+		switch {
+		case float64(sample.BPM) < zone2Bound:
+			breakdown.Zone1Seconds += segmentSec
+		case float64(sample.BPM) < zone3Bound:
+			breakdown.Zone2Seconds += segmentSec
+		case float64(sample.BPM) < zone4Bound:
+			breakdown.Zone3Seconds += segmentSec
+		case float64(sample.BPM) < zone5Bound:
+			breakdown.Zone4Seconds += segmentSec
+		default:
+			breakdown.Zone5Seconds += segmentSec
+		}
+	}
+
+	breakdown.MinHR = minHR
+	breakdown.MaxHR = maxHR
+	breakdown.AvgHR = sumHR / len(samples)
+
+	var totalSeconds = breakdown.Zone1Seconds + breakdown.Zone2Seconds + breakdown.Zone3Seconds +
+		breakdown.Zone4Seconds + breakdown.Zone5Seconds
+	if totalSeconds == 0 {
+		return breakdown
+	}
+
+	breakdown.Zone1Percent = float64(breakdown.Zone1Seconds) / float64(totalSeconds) * 100
+	breakdown.Zone2Percent = float64(breakdown.Zone2Seconds) / float64(totalSeconds) * 100
+	breakdown.Zone3Percent = float64(breakdown.Zone3Seconds) / float64(totalSeconds) * 100
+	breakdown.Zone4Percent = float64(breakdown.Zone4Seconds) / float64(totalSeconds) * 100
+	breakdown.Zone5Percent = float64(breakdown.Zone5Seconds) / float64(totalSeconds) * 100
+
+	return breakdown
+}
+
+// keytelCaloriesPerHour возвращает расход калорий в час по формуле Кейтеля
+// для заданного пульса hr.
+func keytelCaloriesPerHour(hr, weight, ageYears float64, sex Sex) float64 {
+	if sex == Male {
+		return ((keytelMaleConst + keytelMaleHR*hr + keytelMaleWeight*weight + keytelMaleAge*ageYears) / keytelJoulesInKcal) * minInHourFloat
+	}
+
+	return ((keytelFemaleConst + keytelFemaleHR*hr + keytelFemaleWeight*weight + keytelFemaleAge*ageYears) / keytelJoulesInKcal) * minInHourFloat
+}
+
+// HeartRateSpentCalories возвращает количество потраченных калорий по
+// формуле Кейтеля, просуммированное по отметкам пульса samples.
+//
+// Параметры:
+//
+// samples []HRSample — отметки пульса, отсортированные по TimeOffsetSec.
+// weight, ageYears float64 — вес и возраст пользователя.
+// sex Sex — пол пользователя.
+// durationHours float64 — длительность тренировки в часах, используется, если отметка пульса только одна.
+func HeartRateSpentCalories(samples []HRSample, weight, ageYears float64, sex Sex, durationHours float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	if len(samples) == 1 {
+		return keytelCaloriesPerHour(float64(samples[0].BPM), weight, ageYears, sex) * durationHours
+	}
+
+	var total float64
+	for i := 0; i < len(samples)-1; i++ {
+		var segmentHours = float64(samples[i+1].TimeOffsetSec-samples[i].TimeOffsetSec) / secInHour
+		if segmentHours <= 0 {
+			continue
+		}
+
+		total += keytelCaloriesPerHour(float64(samples[i].BPM), weight, ageYears, sex) * segmentHours
+	}
+
+	return total
+}
+
+// formatHRZoneTable форматирует разбивку по зонам пульса в виде, пригодном
+// для вставки в вывод ShowTrainingInfo.
+func formatHRZoneTable(breakdown HRZoneBreakdown) string {
+	var formatMessage = "Пульс: мин. %d / сред. %d / макс. %d уд/мин\n" +
+		"Зона 1 (разминка): %.0f%%\n" +
+		"Зона 2 (жиросжигание): %.0f%%\n" +
+		"Зона 3 (кардио): %.0f%%\n" +
+		"Зона 4 (анаэробная): %.0f%%\n" +
+		"Зона 5 (максимальная): %.0f%%\n"
+
+	return fmt.Sprintf(
+		formatMessage,
+		breakdown.MinHR, breakdown.AvgHR, breakdown.MaxHR,
+		breakdown.Zone1Percent, breakdown.Zone2Percent, breakdown.Zone3Percent,
+		breakdown.Zone4Percent, breakdown.Zone5Percent,
+	)
+}
+
+// ShowTrainingInfoWithHeartRate работает как ShowTrainingInfo, но дополнительно
+// добавляет в вывод таблицу зон пульса, посчитанную по samples.
+//
+// Параметры:
+//
+// samples []HRSample — отметки пульса за тренировку; если пустой, зона пульса не выводится.
+// ageYears, restingHR int — возраст и пульс покоя пользователя, нужны для расчета зон методом Карвонена.
+func ShowTrainingInfoWithHeartRate(
+	action int, trainingType string, duration, weight, height float64, lengthPool, countPool int,
+	ascentM, descentM float64, samples []HRSample, ageYears, restingHR int,
+) string {
+	var info = ShowTrainingInfo(action, trainingType, duration, weight, height, lengthPool, countPool, ascentM, descentM)
+
+	if len(samples) == 0 {
+		return info
+	}
+
+	return info + formatHRZoneTable(HeartRateZones(samples, ageYears, restingHR))
+}
@@ -0,0 +1,52 @@
+package ftracker
+
+import "testing"
+
+func TestHeartRateZones_Empty(t *testing.T) {
+	var got = HeartRateZones(nil, 30, 60)
+
+	if got != (HRZoneBreakdown{}) {
+		t.Errorf("HeartRateZones(nil, ...) = %+v, ожидался нулевой HRZoneBreakdown", got)
+	}
+}
+
+func TestHeartRateZones_SplitsByKarvonenBounds(t *testing.T) {
+	// HRR = (220-30)-60 = 130. Границы зон: 138, 151, 164, 177.
+	var samples = []HRSample{
+		{TimeOffsetSec: 0, BPM: 120},   // ниже первой границы (Zone1Seconds).
+		{TimeOffsetSec: 600, BPM: 160}, // зона 3 (Zone3Seconds).
+		{TimeOffsetSec: 1200, BPM: 160},
+	}
+
+	var got = HeartRateZones(samples, 30, 60)
+
+	if got.MinHR != 120 || got.MaxHR != 160 {
+		t.Errorf("MinHR/MaxHR = %d/%d, ожидались 120/160", got.MinHR, got.MaxHR)
+	}
+	if got.Zone1Seconds != 600 {
+		t.Errorf("Zone1Seconds = %d, ожидалось 600", got.Zone1Seconds)
+	}
+	if got.Zone3Seconds != 600 {
+		t.Errorf("Zone3Seconds = %d, ожидалось 600", got.Zone3Seconds)
+	}
+	if got.Zone1Percent != 50 || got.Zone3Percent != 50 {
+		t.Errorf("Zone1Percent/Zone3Percent = %v/%v, ожидались 50/50", got.Zone1Percent, got.Zone3Percent)
+	}
+}
+
+func TestHeartRateSpentCalories_SingleSampleUsesDuration(t *testing.T) {
+	var samples = []HRSample{{TimeOffsetSec: 0, BPM: 140}}
+
+	var got = HeartRateSpentCalories(samples, 70, 30, Male, 1)
+	var want = keytelCaloriesPerHour(140, 70, 30, Male)
+
+	if got != want {
+		t.Errorf("HeartRateSpentCalories с одной отметкой = %v, ожидалось %v", got, want)
+	}
+}
+
+func TestHeartRateSpentCalories_Empty(t *testing.T) {
+	if got := HeartRateSpentCalories(nil, 70, 30, Male, 1); got != 0 {
+		t.Errorf("HeartRateSpentCalories(nil, ...) = %v, ожидалось 0", got)
+	}
+}
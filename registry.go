@@ -0,0 +1,148 @@
+package ftracker
+
+import "fmt"
+
+// Training - общий интерфейс тренировки. Реализовав его, сторонний код
+// может добавить собственный вид активности, не трогая пакет ftracker.
+type Training interface {
+	// Distance возвращает дистанцию тренировки в километрах.
+	Distance() float64
+	// MeanSpeed возвращает среднюю скорость тренировки в км/ч.
+	MeanSpeed() float64
+	// SpentCalories возвращает количество потраченных калорий.
+	SpentCalories() float64
+	// Summary возвращает строку с информацией о тренировке в формате ShowTrainingInfo.
+	Summary() string
+}
+
+// TrainingFactory создаёт Training по параметрам, переданным пользователем.
+// Набор допустимых ключей params зависит от конкретной реализации.
+type TrainingFactory func(params map[string]any) (Training, error)
+
+// trainingRegistry хранит фабрики, зарегистрированные под именем вида тренировки.
+var trainingRegistry = map[string]TrainingFactory{}
+
+// Register регистрирует фабрику тренировки под именем name. Повторная
+// регистрация того же имени переопределяет предыдущую фабрику.
+func Register(name string, factory TrainingFactory) {
+	trainingRegistry[name] = factory
+}
+
+func init() {
+	Register("Бег", newRunTraining)
+	Register("Ходьба", newWalkTraining)
+	Register("Плавание", newSwimTraining)
+	Register("Силовая", newStrengthTraining)
+	Register("Велосипед", newCyclingTraining)
+	Register("Гребля", newRowingTraining)
+}
+
+// paramFloat64 достаёт из params число с плавающей точкой по ключу key.
+func paramFloat64(params map[string]any, key string) (float64, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("ftracker: отсутствует параметр %q", key)
+	}
+
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("ftracker: параметр %q имеет неверный тип", key)
+	}
+}
+
+// paramFloat64OrDefault работает как paramFloat64, но вместо ошибки при
+// отсутствующем или некорректном параметре возвращает def. Используется для
+// необязательных параметров вроде набора/сброса высоты.
+func paramFloat64OrDefault(params map[string]any, key string, def float64) float64 {
+	v, err := paramFloat64(params, key)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// paramInt достаёт из params целое число по ключу key.
+func paramInt(params map[string]any, key string) (int, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("ftracker: отсутствует параметр %q", key)
+	}
+
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case float64:
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("ftracker: параметр %q имеет неверный тип", key)
+	}
+}
+
+// formatSummary форматирует строку с итогами тренировки в едином для всех
+// видов тренировок виде.
+func formatSummary(trainingType string, duration, distance, speed, calories float64) string {
+	var formatMessage = "Тип тренировки: %s\n" +
+		"Длительность: %.2f ч.\n" +
+		"Дистанция: %.2f км.\n" +
+		"Скорость: %.2f км/ч\n" +
+		"Сожгли калорий: %.2f\n"
+
+	return fmt.Sprintf(
+		formatMessage,
+		trainingType, duration, distance, speed, calories,
+	)
+}
+
+// FormatTrainingSummary форматирует уже посчитанные метрики тренировки в том
+// же виде, в котором их отдаёт ShowTrainingInfo. Предназначена для кода,
+// который получает тренировки не через реестр (например, импортированные из
+// внешнего формата), чтобы оба пути вывода не могли разойтись.
+func FormatTrainingSummary(trainingType string, duration, distance, speed, calories float64) string {
+	return formatSummary(trainingType, duration, distance, speed, calories)
+}
+
+// ShowTrainingInfo возвращает строку с информацией о тренировке.
+//
+// Параметры:
+//
+// action int — количество совершенных действий(число шагов при ходьбе и беге, либо гребков при плавании).
+// trainingType string — вид тренировки, зарегистрированный через Register.
+// duration float64 — длительность тренировки в часах.
+// ascentM, descentM float64 — набор/сброс высоты за тренировку, м; для Бега и Ходьбы ненулевые значения
+// переключают расчет калорий на *Graded-варианты с поправкой на уклон по полиному Минетти.
+//
+// ShowTrainingInfo - тонкий диспетчер поверх реестра тренировок: он
+// собирает переданные аргументы в map[string]any и отдаёт их фабрике,
+// зарегистрированной под trainingType.
+func ShowTrainingInfo(
+	action int, trainingType string, duration, weight, height float64, lengthPool, countPool int,
+	ascentM, descentM float64,
+) string {
+	factory, isExist := trainingRegistry[trainingType]
+	if !isExist {
+		return "неизвестный тип тренировки"
+	}
+
+	params := map[string]any{
+		"action":     action,
+		"duration":   duration,
+		"weight":     weight,
+		"height":     height,
+		"lengthPool": lengthPool,
+		"countPool":  countPool,
+		"ascentM":    ascentM,
+		"descentM":   descentM,
+	}
+
+	training, err := factory(params)
+	if err != nil {
+		return "неизвестный тип тренировки"
+	}
+
+	return training.Summary()
+}
@@ -0,0 +1,35 @@
+package ftracker
+
+import "testing"
+
+func TestShowTrainingInfo_UnknownType(t *testing.T) {
+	var got = ShowTrainingInfo(1000, "Неизвестно", 1, 70, 175, 0, 0, 0, 0)
+
+	if got != "неизвестный тип тренировки" {
+		t.Errorf("ShowTrainingInfo с неизвестным видом тренировки вернул %q", got)
+	}
+}
+
+func TestShowTrainingInfo_DispatchesByRegisteredName(t *testing.T) {
+	var run = ShowTrainingInfo(1000, "Бег", 1, 70, 175, 0, 0, 0, 0)
+	var walk = ShowTrainingInfo(1000, "Ходьба", 1, 70, 175, 0, 0, 0, 0)
+
+	if run == walk {
+		t.Errorf("ShowTrainingInfo вернул одинаковый результат для разных видов тренировки: %q", run)
+	}
+}
+
+func TestRegister_OverridesPreviousFactory(t *testing.T) {
+	var calls int
+	Register("Бег", func(params map[string]any) (Training, error) {
+		calls++
+		return newRunTraining(params)
+	})
+	defer Register("Бег", newRunTraining)
+
+	ShowTrainingInfo(1000, "Бег", 1, 70, 175, 0, 0, 0, 0)
+
+	if calls != 1 {
+		t.Errorf("переопределённая фабрика вызвана %d раз, ожидался 1", calls)
+	}
+}
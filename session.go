@@ -0,0 +1,182 @@
+package ftracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segment - один сегмент составной тренировки, например заплыв в триатлоне
+// или раунд кикбоксинга в смешанной тренировке. Поля соответствуют
+// объединению обязательных параметров видов тренировок из trainingRegistry;
+// заполняются только те, что нужны для Type данного сегмента. Набора/сброса
+// высоты в сегменте нет, поэтому Бег и Ходьба в сессии считаются по формулам
+// для ровной поверхности.
+type Segment struct {
+	Type       TrainingType
+	Action     int
+	Duration   float64
+	Weight     float64
+	Height     float64
+	LengthPool int
+	CountPool  int
+
+	// Для Cycling.
+	Cadence             float64
+	WheelCircumferenceM float64
+
+	// Для Rowing.
+	Strokes int
+
+	// Для Strength.
+	Sets         int
+	Reps         int
+	WeightLifted float64
+}
+
+// trainingTypeNames - обратное соответствие TrainingType -> имя, под которым
+// вид тренировки зарегистрирован в AvailableTrainings и trainingRegistry.
+var trainingTypeNames = invertAvailableTrainings()
+
+func invertAvailableTrainings() map[TrainingType]string {
+	var names = make(map[TrainingType]string, len(AvailableTrainings))
+
+	for name, trainingType := range AvailableTrainings {
+		names[trainingType] = name
+	}
+
+	return names
+}
+
+// toTraining собирает Training для сегмента через тот же реестр фабрик, что
+// использует ShowTrainingInfo.
+func (s Segment) toTraining() (Training, error) {
+	name, isExist := trainingTypeNames[s.Type]
+	if !isExist {
+		return nil, fmt.Errorf("ftracker: неизвестный вид тренировки: %d", s.Type)
+	}
+
+	factory, isExist := trainingRegistry[name]
+	if !isExist {
+		return nil, fmt.Errorf("ftracker: для вида тренировки %q не зарегистрирована фабрика", name)
+	}
+
+	params := map[string]any{
+		"action":       s.Action,
+		"duration":     s.Duration,
+		"weight":       s.Weight,
+		"height":       s.Height,
+		"lengthPool":   s.LengthPool,
+		"countPool":    s.CountPool,
+		"strokes":      s.Strokes,
+		"sets":         s.Sets,
+		"reps":         s.Reps,
+		"weightLifted": s.WeightLifted,
+	}
+	if s.Cadence != 0 {
+		params["cadence"] = s.Cadence
+	}
+	if s.WheelCircumferenceM != 0 {
+		params["wheelCircumferenceM"] = s.WheelCircumferenceM
+	}
+
+	return factory(params)
+}
+
+// Session - одна тренировочная сессия, состоящая из упорядоченных сегментов,
+// каждый из которых может быть своим видом тренировки (например, заплыв,
+// затем велоэтап, затем бег в триатлоне).
+type Session struct {
+	Segments    []Segment
+	PrimaryType TrainingType
+}
+
+// TotalDistance возвращает суммарную дистанцию всех сегментов сессии, км.
+func (s *Session) TotalDistance() float64 {
+	var total float64
+
+	for _, segment := range s.Segments {
+		training, err := segment.toTraining()
+		if err != nil {
+			continue
+		}
+
+		total += training.Distance()
+	}
+
+	return total
+}
+
+// TotalDuration возвращает суммарную длительность всех сегментов сессии, ч.
+func (s *Session) TotalDuration() float64 {
+	var total float64
+
+	for _, segment := range s.Segments {
+		total += segment.Duration
+	}
+
+	return total
+}
+
+// TotalCalories возвращает суммарные калории всех сегментов сессии.
+func (s *Session) TotalCalories() float64 {
+	var total float64
+
+	for _, segment := range s.Segments {
+		training, err := segment.toTraining()
+		if err != nil {
+			continue
+		}
+
+		total += training.SpentCalories()
+	}
+
+	return total
+}
+
+// MeanSpeed возвращает среднюю скорость по сессии целиком, км/ч.
+func (s *Session) MeanSpeed() float64 {
+	var duration = s.TotalDuration()
+	if duration == 0 {
+		return 0
+	}
+
+	return s.TotalDistance() / duration
+}
+
+// Summary возвращает строку с итогами по сессии целиком и разбивкой по
+// каждому сегменту в формате ShowTrainingInfo.
+func (s *Session) Summary() string {
+	var sb strings.Builder
+
+	sb.WriteString(formatSummary(
+		trainingTypeNames[s.PrimaryType], s.TotalDuration(), s.TotalDistance(), s.MeanSpeed(), s.TotalCalories(),
+	))
+
+	for i, segment := range s.Segments {
+		training, err := segment.toTraining()
+		if err != nil {
+			fmt.Fprintf(&sb, "Сегмент %d: %v\n", i+1, err)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "--- Сегмент %d ---\n%s", i+1, training.Summary())
+	}
+
+	return sb.String()
+}
+
+// NewMultiSportSession собирает Session из сегментов segments и выбирает
+// PrimaryType как тип сегмента с наибольшей длительностью.
+func NewMultiSportSession(segments ...Segment) *Session {
+	var session = &Session{Segments: segments}
+
+	var longestDuration = -1.0
+	for _, segment := range segments {
+		if segment.Duration > longestDuration {
+			longestDuration = segment.Duration
+			session.PrimaryType = segment.Type
+		}
+	}
+
+	return session
+}
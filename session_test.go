@@ -0,0 +1,87 @@
+package ftracker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSession_TotalsSumAcrossSegments(t *testing.T) {
+	var session = NewMultiSportSession(
+		Segment{Type: Swim, Action: 0, Duration: 0.5, Weight: 70, LengthPool: 25, CountPool: 40},
+		Segment{Type: Run, Action: 1000, Duration: 1, Weight: 70, Height: 175},
+	)
+
+	var wantDistance = float64(25*40)/mInKm + distance(1000)
+	if got := session.TotalDistance(); got != wantDistance {
+		t.Errorf("TotalDistance() = %v, ожидалось %v", got, wantDistance)
+	}
+
+	var wantDuration = 0.5 + 1.0
+	if got := session.TotalDuration(); got != wantDuration {
+		t.Errorf("TotalDuration() = %v, ожидалось %v", got, wantDuration)
+	}
+
+	var wantCalories = SwimmingSpentCalories(25, 40, 0.5, 70) + RunningSpentCalories(1000, 70, 1)
+	if got := session.TotalCalories(); got != wantCalories {
+		t.Errorf("TotalCalories() = %v, ожидалось %v", got, wantCalories)
+	}
+}
+
+func TestNewMultiSportSession_PrimaryTypeIsLongestSegment(t *testing.T) {
+	var session = NewMultiSportSession(
+		Segment{Type: Swim, Duration: 0.5},
+		Segment{Type: Run, Duration: 1},
+		Segment{Type: Cycling, Duration: 0.75},
+	)
+
+	if session.PrimaryType != Run {
+		t.Errorf("PrimaryType = %v, ожидался Run (самый долгий сегмент)", session.PrimaryType)
+	}
+}
+
+func TestSession_SkipsSegmentsWithUnknownType(t *testing.T) {
+	var session = NewMultiSportSession(Segment{Type: TrainingType(999), Duration: 1, Action: 1000})
+
+	if got := session.TotalDistance(); got != 0 {
+		t.Errorf("TotalDistance() с неизвестным видом тренировки = %v, ожидалось 0", got)
+	}
+	if got := session.TotalCalories(); got != 0 {
+		t.Errorf("TotalCalories() с неизвестным видом тренировки = %v, ожидалось 0", got)
+	}
+}
+
+func TestSession_SwimBikeRun_CyclingSegmentContributes(t *testing.T) {
+	var swim = Segment{Type: Swim, Duration: 0.5, Weight: 70, LengthPool: 25, CountPool: 40}
+	var bike = Segment{Type: Cycling, Duration: 1, Weight: 70, Cadence: 80}
+	var run = Segment{Type: Run, Action: 1000, Duration: 1, Weight: 70, Height: 175}
+
+	var session = NewMultiSportSession(swim, bike, run)
+
+	var bikeDistance = cyclingDistance(bike.Cadence, cyclingDefaultWheelCircumferenceM, bike.Duration)
+	var bikeCalories = CyclingSpentCalories(bike.Cadence, cyclingDefaultWheelCircumferenceM, bike.Weight, bike.Duration)
+
+	var wantDistance = float64(swim.LengthPool*swim.CountPool)/mInKm + bikeDistance + distance(run.Action)
+	var wantCalories = SwimmingSpentCalories(swim.LengthPool, swim.CountPool, swim.Duration, swim.Weight) +
+		bikeCalories + RunningSpentCalories(run.Action, run.Weight, run.Duration)
+
+	if got := session.TotalDistance(); got != wantDistance {
+		t.Errorf("TotalDistance() = %v, ожидалось %v (велоэтап должен учитываться, а не выпадать молча)", got, wantDistance)
+	}
+	if got := session.TotalCalories(); got != wantCalories {
+		t.Errorf("TotalCalories() = %v, ожидалось %v (велоэтап должен учитываться, а не выпадать молча)", got, wantCalories)
+	}
+}
+
+func TestSession_Summary_SurfacesRealParamError(t *testing.T) {
+	// У велоэтапа не задан обязательный параметр cadence - это не то же
+	// самое, что неизвестный вид тренировки, и текст ошибки должен это отражать.
+	var session = NewMultiSportSession(Segment{Type: Cycling, Duration: 1, Weight: 70})
+
+	var got = session.Summary()
+	if strings.Contains(got, "неизвестный вид тренировки") {
+		t.Errorf("Summary() = %q маскирует отсутствующий параметр как неизвестный вид тренировки", got)
+	}
+	if !strings.Contains(got, "cadence") {
+		t.Errorf("Summary() = %q не упоминает отсутствующий параметр cadence", got)
+	}
+}
@@ -0,0 +1,170 @@
+package ftracker
+
+import "time"
+
+// Period - шаг группировки тренировок по времени в AggregateByPeriod.
+type Period int
+
+const (
+	Day Period = iota
+	Week
+	Month
+	Year
+)
+
+// WorkoutRecord - одна завершенная тренировка, пригодная для агрегации.
+// Поля Action/Weight/Height/LengthPool/CountPool повторяют параметры
+// ShowTrainingInfo, чтобы калории считались теми же формулами.
+type WorkoutRecord struct {
+	Type       TrainingType
+	Start      time.Time
+	Duration   float64 // длительность тренировки в часах.
+	Action     int
+	Weight     float64
+	Height     float64
+	LengthPool int
+	CountPool  int
+	AscentM    float64
+	DescentM   float64
+}
+
+// Totals - сводка по одному или нескольким WorkoutRecord.
+type Totals struct {
+	NbWorkouts       int
+	TotalDistanceKm  float64
+	TotalDurationSec float64
+	TotalCalories    float64
+	TotalAscentM     float64
+	TotalDescentM    float64
+	AvgSpeedKmH      float64
+}
+
+// recordDistanceKm возвращает дистанцию записи в километрах: для Плавания -
+// по длине и числу пересечений бассейна, для всех остальных видов - по числу
+// шагов Action, как у Бега и Ходьбы в ShowTrainingInfo.
+func recordDistanceKm(r WorkoutRecord) float64 {
+	if r.Type == Swim {
+		return float64(r.LengthPool) * float64(r.CountPool) / mInKm
+	}
+
+	return distance(r.Action)
+}
+
+// recordCalories возвращает калории записи, посчитанные той же формулой,
+// что и ShowTrainingInfo для соответствующего вида тренировки на ровной
+// поверхности: AscentM/DescentM в расчёт калорий не входят. Для видов
+// тренировок, не предусмотренных исходным пакетом, возвращается 0.
+func recordCalories(r WorkoutRecord) float64 {
+	switch r.Type {
+	case Run:
+		return RunningSpentCalories(r.Action, r.Weight, r.Duration)
+	case Walk:
+		return WalkingSpentCalories(r.Action, r.Duration, r.Weight, r.Height)
+	case Swim:
+		return SwimmingSpentCalories(r.LengthPool, r.CountPool, r.Duration, r.Weight)
+	default:
+		return 0
+	}
+}
+
+// addRecordToTotals добавляет запись r к накопленным итогам totals.
+// AvgSpeedKmH в totals не пересчитывается - это финальный шаг, который
+// выполняется один раз после того, как все записи корзины учтены.
+func addRecordToTotals(totals *Totals, r WorkoutRecord) {
+	totals.NbWorkouts++
+	totals.TotalDistanceKm += recordDistanceKm(r)
+	totals.TotalDurationSec += r.Duration * secInHour
+	totals.TotalCalories += recordCalories(r)
+	totals.TotalAscentM += r.AscentM
+	totals.TotalDescentM += r.DescentM
+}
+
+// finalizeAvgSpeed пересчитывает AvgSpeedKmH по накопленным totals.
+func finalizeAvgSpeed(totals Totals) Totals {
+	if totals.TotalDurationSec > 0 {
+		totals.AvgSpeedKmH = totals.TotalDistanceKm / (totals.TotalDurationSec / secInHour)
+	}
+
+	return totals
+}
+
+// AggregateBySport группирует записи records по виду тренировки и считает
+// по каждому виду сводные итоги.
+func AggregateBySport(records []WorkoutRecord) map[TrainingType]Totals {
+	var result = map[TrainingType]Totals{}
+
+	for _, r := range records {
+		var totals = result[r.Type]
+		addRecordToTotals(&totals, r)
+		result[r.Type] = totals
+	}
+
+	for sport, totals := range result {
+		result[sport] = finalizeAvgSpeed(totals)
+	}
+
+	return result
+}
+
+// periodBucketStart возвращает начало корзины периода period, в которую
+// попадает момент t, приведённый к часовому поясу loc. Неделя считается по
+// ISO-8601, то есть начинается с понедельника.
+func periodBucketStart(t time.Time, period Period, loc *time.Location) time.Time {
+	var local = t.In(loc)
+
+	switch period {
+	case Day:
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	case Week:
+		var weekday = int(local.Weekday())
+		if weekday == 0 {
+			weekday = 7 // time.Sunday == 0, а в ISO-8601 воскресенье - седьмой день недели.
+		}
+
+		var dayStart = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+		return dayStart.AddDate(0, 0, -(weekday - 1))
+	case Month:
+		return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+	case Year:
+		return time.Date(local.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		return local
+	}
+}
+
+// AggregateByPeriod группирует записи records по корзинам периода period
+// (в часовом поясе loc) и внутри каждой корзины - по виду тренировки.
+//
+// Параметры:
+//
+// loc *time.Location — часовой пояс, в котором считаются границы корзин; если nil, используется time.UTC.
+func AggregateByPeriod(records []WorkoutRecord, period Period, loc *time.Location) map[time.Time]map[TrainingType]Totals {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var result = map[time.Time]map[TrainingType]Totals{}
+
+	for _, r := range records {
+		var bucket = periodBucketStart(r.Start, period, loc)
+
+		var bySport, isExist = result[bucket]
+		if !isExist {
+			bySport = map[TrainingType]Totals{}
+			result[bucket] = bySport
+		}
+
+		var totals = bySport[r.Type]
+		addRecordToTotals(&totals, r)
+		bySport[r.Type] = totals
+	}
+
+	for _, bySport := range result {
+		for sport, totals := range bySport {
+			bySport[sport] = finalizeAvgSpeed(totals)
+		}
+	}
+
+	return result
+}
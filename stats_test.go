@@ -0,0 +1,67 @@
+package ftracker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregateBySport_GroupsAndSums(t *testing.T) {
+	var records = []WorkoutRecord{
+		{Type: Run, Start: time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), Duration: 1, Action: 1000, Weight: 70},
+		{Type: Run, Start: time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC), Duration: 1, Action: 1000, Weight: 70},
+		{Type: Swim, Start: time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC), Duration: 1, LengthPool: 25, CountPool: 40, Weight: 70},
+	}
+
+	var got = AggregateBySport(records)
+
+	if got[Run].NbWorkouts != 2 {
+		t.Errorf("NbWorkouts[Run] = %d, ожидалось 2", got[Run].NbWorkouts)
+	}
+	if got[Swim].NbWorkouts != 1 {
+		t.Errorf("NbWorkouts[Swim] = %d, ожидалось 1", got[Swim].NbWorkouts)
+	}
+
+	var wantRunDistance = 2 * distance(1000)
+	if got[Run].TotalDistanceKm != wantRunDistance {
+		t.Errorf("TotalDistanceKm[Run] = %v, ожидалось %v", got[Run].TotalDistanceKm, wantRunDistance)
+	}
+
+	var wantAvgSpeed = wantRunDistance / (got[Run].TotalDurationSec / secInHour)
+	if got[Run].AvgSpeedKmH != wantAvgSpeed {
+		t.Errorf("AvgSpeedKmH[Run] = %v, ожидалось %v", got[Run].AvgSpeedKmH, wantAvgSpeed)
+	}
+}
+
+func TestAggregateByPeriod_BucketsByISOWeek(t *testing.T) {
+	// 2026-01-05 - понедельник, 2026-01-11 - воскресенье той же недели.
+	var records = []WorkoutRecord{
+		{Type: Run, Start: time.Date(2026, 1, 5, 7, 0, 0, 0, time.UTC), Duration: 1, Action: 1000, Weight: 70},
+		{Type: Run, Start: time.Date(2026, 1, 11, 23, 0, 0, 0, time.UTC), Duration: 1, Action: 1000, Weight: 70},
+		{Type: Run, Start: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), Duration: 1, Action: 1000, Weight: 70},
+	}
+
+	var got = AggregateByPeriod(records, Week, time.UTC)
+
+	if len(got) != 2 {
+		t.Fatalf("AggregateByPeriod вернул %d корзин, ожидалось 2", len(got))
+	}
+
+	var weekStart = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	if totals, isExist := got[weekStart]; !isExist || totals[Run].NbWorkouts != 2 {
+		t.Errorf("корзина недели с %v не содержит 2 тренировки: %+v", weekStart, got[weekStart])
+	}
+}
+
+func TestAggregateByPeriod_DefaultsNilLocationToUTC(t *testing.T) {
+	var records = []WorkoutRecord{
+		{Type: Run, Start: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), Duration: 1, Action: 1000, Weight: 70},
+	}
+
+	var withNil = AggregateByPeriod(records, Day, nil)
+	var withUTC = AggregateByPeriod(records, Day, time.UTC)
+
+	var dayStart = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	if withNil[dayStart][Run].NbWorkouts != withUTC[dayStart][Run].NbWorkouts {
+		t.Errorf("AggregateByPeriod(nil) и AggregateByPeriod(time.UTC) разошлись")
+	}
+}
@@ -0,0 +1,404 @@
+package ftracker
+
+// Константы для расчета калорий, расходуемых при силовой тренировке.
+const (
+	strengthCaloriesMET         = 6.0  // условный MET силовой тренировки (подходы на всё тело).
+	strengthVolumeBodyWeightDiv = 1000 // делитель тоннажа, отнесённого к весу тела: каждые 10 весов тела суммарного тоннажа дают 1% надбавки к калоражу.
+)
+
+// Константы для расчета калорий и дистанции при велотренировке.
+const (
+	cyclingCaloriesMeanSpeedMultiplier = 8.0   // множитель средней скорости.
+	cyclingDefaultWheelCircumferenceM  = 2.105 // длина окружности колеса 28" по умолчанию, м.
+)
+
+// Константы для расчета калорий и дистанции при гребле.
+const (
+	rowingLenStroke                   = 3.0  // средняя дистанция, проходимая гребцом за один гребок, м.
+	rowingCaloriesMeanSpeedMultiplier = 9.5  // множитель средней скорости.
+	rowingCaloriesWeightMultiplier    = 0.05 // множитель массы тела.
+)
+
+// runTraining - пробежка, зарегистрированная под именем "Бег".
+type runTraining struct {
+	action   int
+	weight   float64
+	duration float64
+	ascentM  float64
+	descentM float64
+}
+
+func newRunTraining(params map[string]any) (Training, error) {
+	action, err := paramInt(params, "action")
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := paramFloat64(params, "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := paramFloat64(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+
+	var ascentM = paramFloat64OrDefault(params, "ascentM", 0)
+	var descentM = paramFloat64OrDefault(params, "descentM", 0)
+
+	return &runTraining{action: action, weight: weight, duration: duration, ascentM: ascentM, descentM: descentM}, nil
+}
+
+func (t *runTraining) Distance() float64 { return distance(t.action) }
+
+func (t *runTraining) MeanSpeed() float64 { return meanSpeed(t.action, t.duration) }
+
+func (t *runTraining) SpentCalories() float64 {
+	if t.ascentM == 0 && t.descentM == 0 {
+		return RunningSpentCalories(t.action, t.weight, t.duration)
+	}
+
+	var grade = GradeFromElevation(t.ascentM, t.descentM, t.Distance())
+
+	return RunningSpentCaloriesGraded(t.action, t.weight, t.duration, grade)
+}
+
+func (t *runTraining) Summary() string {
+	return formatSummary("Бег", t.duration, t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+// walkTraining - ходьба, зарегистрированная под именем "Ходьба".
+type walkTraining struct {
+	action   int
+	duration float64
+	weight   float64
+	height   float64
+	ascentM  float64
+	descentM float64
+}
+
+func newWalkTraining(params map[string]any) (Training, error) {
+	action, err := paramInt(params, "action")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := paramFloat64(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := paramFloat64(params, "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := paramFloat64(params, "height")
+	if err != nil {
+		return nil, err
+	}
+
+	var ascentM = paramFloat64OrDefault(params, "ascentM", 0)
+	var descentM = paramFloat64OrDefault(params, "descentM", 0)
+
+	return &walkTraining{
+		action: action, duration: duration, weight: weight, height: height, ascentM: ascentM, descentM: descentM,
+	}, nil
+}
+
+func (t *walkTraining) Distance() float64 { return distance(t.action) }
+
+func (t *walkTraining) MeanSpeed() float64 { return meanSpeed(t.action, t.duration) }
+
+func (t *walkTraining) SpentCalories() float64 {
+	if t.ascentM == 0 && t.descentM == 0 {
+		return WalkingSpentCalories(t.action, t.duration, t.weight, t.height)
+	}
+
+	var grade = GradeFromElevation(t.ascentM, t.descentM, t.Distance())
+
+	return WalkingSpentCaloriesGraded(t.action, t.duration, t.weight, t.height, grade)
+}
+
+func (t *walkTraining) Summary() string {
+	return formatSummary("Ходьба", t.duration, t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+// swimTraining - плавание, зарегистрированное под именем "Плавание".
+type swimTraining struct {
+	lengthPool int
+	countPool  int
+	duration   float64
+	weight     float64
+}
+
+func newSwimTraining(params map[string]any) (Training, error) {
+	lengthPool, err := paramInt(params, "lengthPool")
+	if err != nil {
+		return nil, err
+	}
+
+	countPool, err := paramInt(params, "countPool")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := paramFloat64(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := paramFloat64(params, "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	return &swimTraining{lengthPool: lengthPool, countPool: countPool, duration: duration, weight: weight}, nil
+}
+
+func (t *swimTraining) Distance() float64 {
+	return float64(t.lengthPool) * float64(t.countPool) / mInKm
+}
+
+func (t *swimTraining) MeanSpeed() float64 {
+	return swimmingMeanSpeed(t.lengthPool, t.countPool, t.duration)
+}
+
+func (t *swimTraining) SpentCalories() float64 {
+	return SwimmingSpentCalories(t.lengthPool, t.countPool, t.duration, t.weight)
+}
+
+func (t *swimTraining) Summary() string {
+	return formatSummary("Плавание", t.duration, t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+// strengthTraining - силовая тренировка (подходы x повторения x вес), не
+// привязанная к пройденной дистанции.
+type strengthTraining struct {
+	sets         int
+	reps         int
+	weightLifted float64
+	bodyWeight   float64
+	duration     float64
+}
+
+func newStrengthTraining(params map[string]any) (Training, error) {
+	sets, err := paramInt(params, "sets")
+	if err != nil {
+		return nil, err
+	}
+
+	reps, err := paramInt(params, "reps")
+	if err != nil {
+		return nil, err
+	}
+
+	weightLifted, err := paramFloat64(params, "weightLifted")
+	if err != nil {
+		return nil, err
+	}
+
+	bodyWeight, err := paramFloat64(params, "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := paramFloat64(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+
+	return &strengthTraining{
+		sets: sets, reps: reps, weightLifted: weightLifted, bodyWeight: bodyWeight, duration: duration,
+	}, nil
+}
+
+func (t *strengthTraining) Distance() float64 { return 0 }
+
+func (t *strengthTraining) MeanSpeed() float64 { return 0 }
+
+func (t *strengthTraining) SpentCalories() float64 {
+	return StrengthSpentCalories(t.sets, t.reps, t.weightLifted, t.bodyWeight, t.duration)
+}
+
+func (t *strengthTraining) Summary() string {
+	return formatSummary("Силовая", t.duration, t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+// StrengthSpentCalories возвращает количество потраченных калорий при
+// силовой тренировке, рассчитанное через MET с поправкой на суммарный тоннаж.
+//
+// Параметры:
+//
+// sets, reps int — количество подходов и повторений в подходе.
+// weightLifted float64 — рабочий вес, кг.
+// bodyWeight float64 — вес пользователя, кг.
+// duration float64 — длительность тренировки в часах.
+func StrengthSpentCalories(sets, reps int, weightLifted, bodyWeight, duration float64) float64 {
+	var volume = float64(sets*reps) * weightLifted
+	var intensityFactor = 1 + volume/(bodyWeight*strengthVolumeBodyWeightDiv)
+
+	return strengthCaloriesMET * bodyWeight * duration * intensityFactor
+}
+
+// cyclingTraining - велотренировка, использующая каденс и длину окружности
+// колеса вместо lenStep.
+type cyclingTraining struct {
+	cadence             float64
+	wheelCircumferenceM float64
+	weight              float64
+	duration            float64
+}
+
+func newCyclingTraining(params map[string]any) (Training, error) {
+	cadence, err := paramFloat64(params, "cadence")
+	if err != nil {
+		return nil, err
+	}
+
+	wheelCircumferenceM, err := paramFloat64(params, "wheelCircumferenceM")
+	if err != nil {
+		wheelCircumferenceM = cyclingDefaultWheelCircumferenceM
+	}
+
+	weight, err := paramFloat64(params, "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := paramFloat64(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+
+	return &cyclingTraining{
+		cadence: cadence, wheelCircumferenceM: wheelCircumferenceM, weight: weight, duration: duration,
+	}, nil
+}
+
+func (t *cyclingTraining) Distance() float64 {
+	return cyclingDistance(t.cadence, t.wheelCircumferenceM, t.duration)
+}
+
+func (t *cyclingTraining) MeanSpeed() float64 {
+	if t.duration == 0 {
+		return 0
+	}
+
+	return t.Distance() / t.duration
+}
+
+func (t *cyclingTraining) SpentCalories() float64 {
+	return CyclingSpentCalories(t.cadence, t.wheelCircumferenceM, t.weight, t.duration)
+}
+
+func (t *cyclingTraining) Summary() string {
+	return formatSummary("Велосипед", t.duration, t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+// cyclingDistance возвращает дистанцию (в километрах), пройденную на
+// велосипеде за время тренировки.
+//
+// Параметры:
+//
+// cadence float64 — каденс, оборотов колеса в минуту.
+// wheelCircumferenceM float64 — длина окружности колеса, м.
+// duration float64 — длительность тренировки в часах.
+func cyclingDistance(cadence, wheelCircumferenceM, duration float64) float64 {
+	return cadence * wheelCircumferenceM * minInHour * duration / mInKm
+}
+
+// CyclingSpentCalories возвращает количество потраченных калорий при
+// велотренировке.
+//
+// Параметры:
+//
+// cadence float64 — каденс, оборотов колеса в минуту.
+// wheelCircumferenceM float64 — длина окружности колеса, м.
+// weight float64 — вес пользователя.
+// duration float64 — длительность тренировки в часах.
+func CyclingSpentCalories(cadence, wheelCircumferenceM, weight, duration float64) float64 {
+	if duration == 0 {
+		return 0
+	}
+
+	var meanSpeedInKmH = cyclingDistance(cadence, wheelCircumferenceM, duration) / duration
+
+	return cyclingCaloriesMeanSpeedMultiplier * meanSpeedInKmH * weight / mInKm * duration * minInHour
+}
+
+// rowingTraining - гребля, зарегистрированная под именем "Гребля".
+type rowingTraining struct {
+	strokes  int
+	weight   float64
+	duration float64
+}
+
+func newRowingTraining(params map[string]any) (Training, error) {
+	strokes, err := paramInt(params, "strokes")
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := paramFloat64(params, "weight")
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := paramFloat64(params, "duration")
+	if err != nil {
+		return nil, err
+	}
+
+	return &rowingTraining{strokes: strokes, weight: weight, duration: duration}, nil
+}
+
+func (t *rowingTraining) Distance() float64 { return rowingDistance(t.strokes) }
+
+func (t *rowingTraining) MeanSpeed() float64 { return rowingMeanSpeed(t.strokes, t.duration) }
+
+func (t *rowingTraining) SpentCalories() float64 {
+	return RowingSpentCalories(t.strokes, t.weight, t.duration)
+}
+
+func (t *rowingTraining) Summary() string {
+	return formatSummary("Гребля", t.duration, t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+// rowingDistance возвращает дистанцию (в километрах), преодолённую гребцом
+// за время тренировки.
+//
+// Параметры:
+//
+// strokes int — количество выполненных гребков.
+func rowingDistance(strokes int) float64 {
+	return float64(strokes) * rowingLenStroke / mInKm
+}
+
+// rowingMeanSpeed возвращает среднюю скорость при гребле.
+//
+// Параметры:
+//
+// strokes int — количество выполненных гребков.
+// duration float64 — длительность тренировки в часах.
+func rowingMeanSpeed(strokes int, duration float64) float64 {
+	if duration == 0 {
+		return 0
+	}
+
+	return rowingDistance(strokes) / duration
+}
+
+// RowingSpentCalories возвращает количество потраченных калорий при гребле.
+//
+// Параметры:
+//
+// strokes int — количество выполненных гребков.
+// weight float64 — вес пользователя.
+// duration float64 — длительность тренировки в часах.
+func RowingSpentCalories(strokes int, weight, duration float64) float64 {
+	var meanSpeedInKmH = rowingMeanSpeed(strokes, duration)
+
+	return (rowingCaloriesMeanSpeedMultiplier*meanSpeedInKmH + rowingCaloriesWeightMultiplier*weight) * duration * minInHour
+}